@@ -0,0 +1,233 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Defaults for the BGP speaker, mirroring gobgp's own defaults.
+const (
+	defaultBGPHoldTime    = 90
+	defaultBGPKeepalive   = 30
+	defaultBGPRestartTime = 120
+)
+
+// minASN and maxASN bound the autonomous system numbers accepted in
+// LocalASN/RemoteASN, covering the full 4-byte ASN range from RFC 6793.
+const (
+	minASN = 1
+	maxASN = 4294967295
+)
+
+// BGPSpec defines the configuration options for announcing the control plane
+// VIPs via BGP, using an embedded gobgp speaker running on every control
+// plane node. This package only defines and validates the schema; the
+// speaker itself is run and reconciled from this spec elsewhere.
+type BGPSpec struct {
+	// LocalASN is the local autonomous system number used by the gobgp
+	// speaker. Accepts both 2-byte and 4-byte ASNs, as defined in RFC 6793.
+	// +kubebuilder:validation:Required
+	LocalASN int64 `json:"localASN"`
+
+	// RouterID is the BGP router ID advertised to peers. Must be a valid
+	// IPv4 address. If not specified, k0s will use the address of the
+	// default route's interface.
+	// +optional
+	RouterID string `json:"routerID,omitempty"`
+
+	// Neighbors is the list of BGP peers the speaker establishes sessions
+	// with.
+	Neighbors []BGPNeighbor `json:"neighbors,omitempty"`
+
+	// VIPs is the list of virtual IPs advertised to the configured
+	// neighbors.
+	VIPs []BGPVIP `json:"vips,omitempty"`
+
+	// GracefulRestart configures BGP graceful restart for the speaker.
+	// +optional
+	GracefulRestart *BGPGracefulRestart `json:"gracefulRestart,omitempty"`
+}
+
+// BGPNeighbor defines a single BGP peering session.
+type BGPNeighbor struct {
+	// PeerAddress is the IP address of the neighbor.
+	// +kubebuilder:validation:Required
+	PeerAddress string `json:"peerAddress"`
+
+	// RemoteASN is the autonomous system number of the neighbor. Accepts both
+	// 2-byte and 4-byte ASNs, as defined in RFC 6793.
+	// +kubebuilder:validation:Required
+	RemoteASN int64 `json:"remoteASN"`
+
+	// Password is an optional MD5 password used to authenticate the BGP
+	// session.
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// HoldTime is the BGP hold timer in seconds. If not specified, defaults
+	// to 90.
+	// +kubebuilder:default=90
+	// +optional
+	HoldTime int32 `json:"holdTime,omitempty"`
+
+	// KeepaliveInterval is the BGP keepalive interval in seconds. If not
+	// specified, defaults to 30.
+	// +kubebuilder:default=30
+	// +optional
+	KeepaliveInterval int32 `json:"keepaliveInterval,omitempty"`
+
+	// EBGPMultiHop allows the session to be established with a neighbor
+	// that is not directly connected.
+	// +optional
+	EBGPMultiHop bool `json:"ebgpMultiHop,omitempty"`
+
+	// SourceAddress is the local address used to establish the session. If
+	// not specified, the address of the outgoing interface is used.
+	// +optional
+	SourceAddress string `json:"sourceAddress,omitempty"`
+}
+
+// BGPNextHopMode describes how the next hop is selected for an advertised
+// VIP.
+// +kubebuilder:validation:Enum=Self;Interface;Explicit
+type BGPNextHopMode string
+
+const (
+	// BGPNextHopSelf uses the speaker's own address as the next hop.
+	BGPNextHopSelf BGPNextHopMode = "Self"
+	// BGPNextHopInterface derives the next hop from the address of
+	// BGPVIP.NextHopInterface.
+	BGPNextHopInterface BGPNextHopMode = "Interface"
+	// BGPNextHopExplicit uses BGPVIP.NextHopAddress verbatim.
+	BGPNextHopExplicit BGPNextHopMode = "Explicit"
+)
+
+// BGPVIP defines a single virtual IP advertised via BGP.
+type BGPVIP struct {
+	// VirtualIP is the virtual IP address advertised by the speaker. Must be
+	// a CIDR as defined in RFC 4632 and RFC 4291.
+	// +kubebuilder:validation:Required
+	VirtualIP string `json:"virtualIP"`
+
+	// Communities is the list of BGP communities attached to the advertised
+	// route, in `asn:value` notation.
+	// +optional
+	Communities []string `json:"communities,omitempty"`
+
+	// NextHop selects how the next hop is chosen for this VIP. If not
+	// specified, defaults to Self.
+	// +kubebuilder:default=Self
+	// +optional
+	NextHop BGPNextHopMode `json:"nextHop,omitempty"`
+
+	// NextHopInterface is the interface whose address is used as the next
+	// hop. Required when NextHop is Interface.
+	// +optional
+	NextHopInterface string `json:"nextHopInterface,omitempty"`
+
+	// NextHopAddress is the address used as the next hop. Required when
+	// NextHop is Explicit.
+	// +optional
+	NextHopAddress string `json:"nextHopAddress,omitempty"`
+}
+
+// BGPGracefulRestart configures BGP graceful restart.
+type BGPGracefulRestart struct {
+	// Enabled indicates if graceful restart should be enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RestartTime is the graceful restart time in seconds advertised to
+	// peers. If not specified, defaults to 120.
+	// +kubebuilder:default=120
+	// +optional
+	RestartTime int32 `json:"restartTime,omitempty"`
+}
+
+// validateBGP validates the BGPSpec and sets the default values of
+// undefined fields. Mirrors validateVRRPInstances/validateVirtualServers.
+func (b *BGPSpec) validateBGP() []error {
+	if b == nil {
+		return nil
+	}
+	errs := []error{}
+
+	if b.LocalASN < minASN || b.LocalASN > maxASN {
+		errs = append(errs, fmt.Errorf("LocalASN must be in the range of %d-%d", minASN, maxASN))
+	}
+
+	if b.RouterID != "" && net.ParseIP(b.RouterID).To4() == nil {
+		errs = append(errs, fmt.Errorf("invalid RouterID: %s", b.RouterID))
+	}
+
+	if len(b.Neighbors) == 0 {
+		errs = append(errs, errors.New("at least one neighbor must be defined"))
+	}
+	for i := range b.Neighbors {
+		n := &b.Neighbors[i]
+		if n.PeerAddress == "" || net.ParseIP(n.PeerAddress) == nil {
+			errs = append(errs, fmt.Errorf("invalid PeerAddress: %s", n.PeerAddress))
+		}
+		if n.RemoteASN < minASN || n.RemoteASN > maxASN {
+			errs = append(errs, fmt.Errorf("RemoteASN must be in the range of %d-%d", minASN, maxASN))
+		}
+		if n.SourceAddress != "" && net.ParseIP(n.SourceAddress) == nil {
+			errs = append(errs, fmt.Errorf("invalid SourceAddress: %s", n.SourceAddress))
+		}
+		if n.HoldTime == 0 {
+			n.HoldTime = defaultBGPHoldTime
+		}
+		if n.KeepaliveInterval == 0 {
+			n.KeepaliveInterval = defaultBGPKeepalive
+		}
+	}
+
+	if len(b.VIPs) == 0 {
+		errs = append(errs, errors.New("at least one VIP must be defined"))
+	}
+	for i := range b.VIPs {
+		v := &b.VIPs[i]
+		if _, _, err := net.ParseCIDR(v.VirtualIP); err != nil {
+			errs = append(errs, fmt.Errorf("VirtualIP must be a CIDR. Got: %s", v.VirtualIP))
+		}
+
+		switch v.NextHop {
+		case "":
+			v.NextHop = BGPNextHopSelf
+		case BGPNextHopSelf:
+		case BGPNextHopInterface:
+			if v.NextHopInterface == "" {
+				errs = append(errs, errors.New("NextHopInterface must be defined when NextHop is Interface"))
+			}
+		case BGPNextHopExplicit:
+			if v.NextHopAddress == "" || net.ParseIP(v.NextHopAddress) == nil {
+				errs = append(errs, fmt.Errorf("invalid NextHopAddress: %s", v.NextHopAddress))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("invalid NextHop: %s", v.NextHop))
+		}
+	}
+
+	if b.GracefulRestart != nil && b.GracefulRestart.RestartTime == 0 {
+		b.GracefulRestart.RestartTime = defaultBGPRestartTime
+	}
+
+	return errs
+}
@@ -20,6 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
 )
 
 // Defaults are keepalived's defaults.
@@ -35,7 +39,10 @@ type ControlPlaneLoadBalancingSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 
 	// type indicates the type of the node-local load balancer to deploy on
-	// worker nodes. Currently, the only supported type is "Keepalived".
+	// control plane nodes. Supported values are "Keepalived" and "BGP". Keepalived
+	// and BGP are not mutually exclusive: both Keepalived and BGP may be
+	// configured at the same time, in which case the VIPs are advertised by
+	// both mechanisms.
 	// +kubebuilder:default=Keepalived
 	// +optional
 	Type CPLBType `json:"type,omitempty"`
@@ -43,16 +50,29 @@ type ControlPlaneLoadBalancingSpec struct {
 	// Keepalived contains configuration options related to the "Keepalived" type
 	// of load balancing.
 	Keepalived *KeepalivedSpec `json:"keepalived,omitempty"`
+
+	// BGP contains configuration options related to the "BGP" type of load
+	// balancing, which advertises the control plane VIP(s) to a set of BGP
+	// neighbors using an embedded gobgp speaker.
+	BGP *BGPSpec `json:"bgp,omitempty"`
+
+	// ServiceLB enables allocating addresses for Kubernetes Service objects
+	// of type LoadBalancer from one or more EIPPool resources, programming
+	// the chosen address into the existing CPLB machinery.
+	// +optional
+	ServiceLB *ServiceLBSpec `json:"serviceLB,omitempty"`
 }
 
 // NllbType describes which type of load balancer should be deployed for the
 // node-local load balancing. The default is [CPLBTypeKeepalived].
-// +kubebuilder:validation:Enum=Keepalived
+// +kubebuilder:validation:Enum=Keepalived;BGP
 type CPLBType string
 
 const (
 	// CPLBTypeKeepalived selects Keepalived as the backing load balancer.
 	CPLBTypeKeepalived CPLBType = "Keepalived"
+	// CPLBTypeBGP selects BGP as the backing load balancer.
+	CPLBTypeBGP CPLBType = "BGP"
 )
 
 type KeepalivedSpec struct {
@@ -62,6 +82,73 @@ type KeepalivedSpec struct {
 	// Configuration options related to the virtual servers. This is an array
 	// which allows to configure multiple load balancers.
 	VirtualServers VirtualServers `json:"virtualServers,omitempty"`
+
+	// VRRPScripts is a list of scripts that can be referenced by name from
+	// VRRPInstance.TrackScripts to gate mastership on external health, such
+	// as kube-apiserver or etcd reachability.
+	VRRPScripts []VRRPScript `json:"vrrpScripts,omitempty"`
+
+	// EnableScriptSecurity, when true, instructs keepalived to refuse to
+	// execute scripts that are owned by root or that are writable by anyone
+	// other than the owner. Corresponds to keepalived's
+	// `enable_script_security` global directive.
+	// +optional
+	EnableScriptSecurity bool `json:"enableScriptSecurity,omitempty"`
+}
+
+// VRRPScript defines a tracking script that can be referenced by name from
+// VRRPInstance.TrackScripts.
+type VRRPScript struct {
+	// Name uniquely identifies the script so it can be referenced from
+	// VRRPInstance.TrackScripts.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Script is the command to run to check the state.
+	// +kubebuilder:validation:Required
+	Script string `json:"script"`
+
+	// Interval is the number of seconds between script invocations. If not
+	// specified, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	Interval int32 `json:"interval,omitempty"`
+
+	// Fall is the number of consecutive failures required before
+	// transitioning to a failed state. If not specified, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	Fall int32 `json:"fall,omitempty"`
+
+	// Rise is the number of consecutive successes required before
+	// transitioning back to a successful state. If not specified, defaults
+	// to 1.
+	// +kubebuilder:default=1
+	// +optional
+	Rise int32 `json:"rise,omitempty"`
+
+	// Timeout is the number of seconds after which the script is considered
+	// to have failed if it has not completed. If not specified, defaults to
+	// the value of Interval.
+	// +optional
+	Timeout int32 `json:"timeout,omitempty"`
+
+	// Weight adjusts the priority of the VRRP instance depending on the
+	// script's result. A negative weight subtracts from the priority on
+	// failure, a positive weight adds to the priority on success. Must be in
+	// the range of -254 to 254.
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+
+	// User is the user the script is run as. If not specified, keepalived's
+	// default (the keepalived_script user, falling back to root) is used.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Group is the group the script is run as. If not specified, defaults to
+	// User's primary group.
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // VRRPInstances is a list of VRRPInstance
@@ -95,8 +182,90 @@ type VRRPInstance struct {
 	// feature but a way to prevent accidental misconfigurations.
 	// Authpass must be 8 characters or less.
 	AuthPass string `json:"authPass"`
+
+	// TrackScripts is a list of KeepalivedSpec.VRRPScripts names to track for
+	// this VRRP instance. A failing tracked script adjusts the instance's
+	// priority, which can trigger a failover.
+	// +optional
+	TrackScripts []string `json:"trackScripts,omitempty"`
+
+	// TrackInterfaces is a list of network interfaces to track for this VRRP
+	// instance. If a tracked interface goes down, the instance's priority is
+	// set to 0, triggering a failover.
+	// +optional
+	TrackInterfaces []string `json:"trackInterfaces,omitempty"`
+
+	// UnicastSourceIP is the local IP address keepalived advertises from
+	// when using unicast instead of multicast VRRP. It must be present on
+	// Interface. If left empty, k0s auto-detects the address of Interface,
+	// both with a static UnicastPeers list and with [UnicastPeersAuto].
+	// +optional
+	UnicastSourceIP string `json:"unicastSourceIP,omitempty"`
+
+	// UnicastPeers is the list of peer IP addresses keepalived sends VRRP
+	// advertisements to directly, instead of the 224.0.0.18 multicast
+	// group. This is required on networks that drop VRRP multicast, such
+	// as most cloud provider VPCs. Set to [UnicastPeersAuto] to have k0s
+	// discover the peers from the other control plane nodes in the
+	// cluster.
+	// +optional
+	UnicastPeers []string `json:"unicastPeers,omitempty"`
+
+	// NotifyMasterScript is run whenever the VRRP instance transitions into
+	// the Master state. Corresponds to keepalived's notify_master
+	// directive.
+	// +optional
+	NotifyMasterScript *NotifyScript `json:"notifyMasterScript,omitempty"`
+
+	// NotifyBackupScript is run whenever the VRRP instance transitions into
+	// the Backup state. Corresponds to keepalived's notify_backup
+	// directive.
+	// +optional
+	NotifyBackupScript *NotifyScript `json:"notifyBackupScript,omitempty"`
+
+	// NotifyFaultScript is run whenever the VRRP instance transitions into
+	// the Fault state. Corresponds to keepalived's notify_fault directive.
+	// +optional
+	NotifyFaultScript *NotifyScript `json:"notifyFaultScript,omitempty"`
+
+	// NotifyScript is run on every VRRP state transition, in addition to
+	// the state-specific scripts above. Corresponds to keepalived's notify
+	// directive.
+	// +optional
+	NotifyScript *NotifyScript `json:"notifyScript,omitempty"`
 }
 
+// NotifyScript defines a command that keepalived runs in reaction to a VRRP
+// state transition. Emitting a corresponding Kubernetes Event is done by the
+// k0s controller that watches keepalived's state, outside this package.
+type NotifyScript struct {
+	// Command is the command to run.
+	// +kubebuilder:validation:Required
+	Command string `json:"command"`
+
+	// User is the user the command is run as. If not specified, keepalived's
+	// default (the keepalived_script user, falling back to root) is used.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Group is the group the command is run as. If not specified, defaults
+	// to User's primary group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Timeout is the number of seconds after which the command is
+	// considered to have failed if it has not completed.
+	// +optional
+	Timeout int32 `json:"timeout,omitempty"`
+}
+
+// UnicastPeersAuto is a sentinel value for VRRPInstance.UnicastPeers that
+// instructs k0s to auto-discover the peers from the addresses of the other
+// control plane nodes in the cluster, instead of using a static list. The
+// discovery itself is implemented by the CPLB reconciler, outside this
+// package; this type only recognizes and validates the sentinel.
+const UnicastPeersAuto = "auto"
+
 type VirtualIPs []string
 
 // validateVRRPInstances validates existing configuration and sets the default
@@ -142,10 +311,183 @@ func (k *KeepalivedSpec) validateVRRPInstances(getDefaultNICFn func() (string, e
 				errs = append(errs, fmt.Errorf("VirtualIPs must be a CIDR. Got: %s", vip))
 			}
 		}
+
+		for _, name := range k.VRRPInstances[i].TrackScripts {
+			if !k.hasVRRPScript(name) {
+				errs = append(errs, fmt.Errorf("TrackScripts references undefined VRRPScript: %s", name))
+			}
+		}
+		for _, iface := range k.VRRPInstances[i].TrackInterfaces {
+			if _, err := net.InterfaceByName(iface); err != nil {
+				logrus.Warnf("TrackInterfaces references an interface not present on this node: %s", iface)
+			}
+		}
+
+		errs = append(errs, validateUnicastPeers(&k.VRRPInstances[i])...)
+
+		for _, notify := range []*NotifyScript{
+			k.VRRPInstances[i].NotifyMasterScript,
+			k.VRRPInstances[i].NotifyBackupScript,
+			k.VRRPInstances[i].NotifyFaultScript,
+			k.VRRPInstances[i].NotifyScript,
+		} {
+			errs = append(errs, validateNotifyScript(notify, k.EnableScriptSecurity)...)
+		}
+	}
+
+	errs = append(errs, k.validateVRRPScripts()...)
+
+	return errs
+}
+
+// validateUnicastPeers validates the UnicastSourceIP/UnicastPeers pair of a
+// VRRPInstance.
+func validateUnicastPeers(v *VRRPInstance) []error {
+	errs := []error{}
+	if len(v.UnicastPeers) == 0 {
+		return errs
+	}
+
+	if len(v.UnicastPeers) == 1 && v.UnicastPeers[0] == UnicastPeersAuto {
+		return errs
+	}
+
+	var source net.IP
+	if v.UnicastSourceIP != "" {
+		source = net.ParseIP(v.UnicastSourceIP)
+		if source == nil {
+			errs = append(errs, fmt.Errorf("invalid UnicastSourceIP: %s", v.UnicastSourceIP))
+		} else if !interfaceHasAddress(v.Interface, source) {
+			errs = append(errs, fmt.Errorf("UnicastSourceIP %s is not present on interface %s", v.UnicastSourceIP, v.Interface))
+		}
+	}
+
+	for _, peer := range v.UnicastPeers {
+		peerIP := net.ParseIP(peer)
+		if peerIP == nil {
+			errs = append(errs, fmt.Errorf("invalid UnicastPeers entry: %s", peer))
+			continue
+		}
+		if source != nil && (peerIP.To4() == nil) != (source.To4() == nil) {
+			errs = append(errs, fmt.Errorf("UnicastPeers entry %s is not the same IP family as UnicastSourceIP %s", peer, v.UnicastSourceIP))
+		}
+		if source != nil && peerIP.Equal(source) {
+			errs = append(errs, fmt.Errorf("UnicastPeers must not contain the local UnicastSourceIP: %s", peer))
+		}
+	}
+
+	return errs
+}
+
+// interfaceHasAddress reports whether the named interface owns the given
+// address. If the interface cannot be inspected, it returns true so that
+// environments where the interface is not yet available (e.g. dry-run
+// validation away from the target node) are not rejected.
+func interfaceHasAddress(name string, ip net.IP) bool {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return true
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return true
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNotifyScript validates a NotifyScript, applying the script
+// security check when enableScriptSecurity is true.
+func validateNotifyScript(n *NotifyScript, enableScriptSecurity bool) []error {
+	if n == nil {
+		return nil
+	}
+	errs := []error{}
+	if n.Command == "" {
+		errs = append(errs, errors.New("NotifyScript Command must be defined"))
+	}
+	if enableScriptSecurity {
+		if err := validateScriptOwnership(n.Command); err != nil {
+			errs = append(errs, fmt.Errorf("NotifyScript fails script security check: %w", err))
+		}
+	}
+	return errs
+}
+
+// hasVRRPScript reports whether a VRRPScript with the given name is defined.
+func (k *KeepalivedSpec) hasVRRPScript(name string) bool {
+	for _, s := range k.VRRPScripts {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateVRRPScripts validates the configured VRRPScripts and sets the
+// default values of undefined fields.
+func (k *KeepalivedSpec) validateVRRPScripts() []error {
+	errs := []error{}
+	for i := range k.VRRPScripts {
+		s := &k.VRRPScripts[i]
+		if s.Name == "" {
+			errs = append(errs, errors.New("VRRPScript Name must be defined"))
+		}
+		if s.Script == "" {
+			errs = append(errs, errors.New("VRRPScript Script must be defined"))
+		}
+		if s.Weight < -254 || s.Weight > 254 {
+			errs = append(errs, fmt.Errorf("VRRPScript Weight must be in the range of -254 to 254. Got: %d", s.Weight))
+		}
+		if s.Interval == 0 {
+			s.Interval = 1
+		}
+		if s.Fall == 0 {
+			s.Fall = 1
+		}
+		if s.Rise == 0 {
+			s.Rise = 1
+		}
+		if s.Timeout == 0 {
+			s.Timeout = s.Interval
+		}
+		if k.EnableScriptSecurity {
+			if err := validateScriptOwnership(s.Script); err != nil {
+				errs = append(errs, fmt.Errorf("VRRPScript %s fails script security check: %w", s.Name, err))
+			}
+		}
 	}
 	return errs
 }
 
+// validateScriptOwnership refuses scripts owned by root or writable by
+// anyone other than the owner, mirroring keepalived's enable_script_security
+// behavior. Validation commonly runs away from the node that will actually
+// execute the script, so a script that can't be stat'd here is soft-warned
+// rather than rejected, the same way TrackInterfaces is handled above.
+func validateScriptOwnership(path string) error {
+	fields := strings.Fields(path)
+	if len(fields) == 0 {
+		return errors.New("script must be defined")
+	}
+	info, err := os.Stat(fields[0])
+	if err != nil {
+		logrus.Warnf("cannot verify script security for %s: %v", fields[0], err)
+		return nil
+	}
+	if isOwnedByRoot(info) {
+		return errors.New("script must not be owned by root")
+	}
+	if info.Mode()&0022 != 0 {
+		return errors.New("script must not be writable by non-owners")
+	}
+	return nil
+}
+
 // VirtualServers is a list of VirtualServer
 // +listType=map
 // +listMapKey=ipAddress
@@ -175,6 +517,15 @@ type VirtualServer struct {
 	// seconds. If not specified, defaults to 360 (6 minutes).
 	// kubebuilder:validation:Minimum=0
 	PersistenceTimeoutSeconds int `json:"persistenceTimeoutSeconds,omitempty"`
+
+	// HealthCheck is the default health check applied to every real server
+	// of this virtual server that does not define its own HealthCheck.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+
+	// RealServers is the list of backend servers for this virtual server.
+	// +optional
+	RealServers []RealServer `json:"realServers,omitempty"`
 }
 
 // KeepalivedLBAlgo describes the load balancing algorithm.
@@ -208,6 +559,17 @@ type RealServer struct {
 	IPAddress string `json:"ipAddress"`
 	// Weight is the weight of the real server. If not specified, defaults to 1.
 	Weight int `json:"weight,omitempty"`
+
+	// InhibitOnFailure, when true, sets the real server's weight to 0 on
+	// health check failure instead of removing it from the pool, so that it
+	// keeps receiving persistent connections.
+	// +optional
+	InhibitOnFailure bool `json:"inhibitOnFailure,omitempty"`
+
+	// HealthCheck overrides the virtual server's default HealthCheck for
+	// this real server.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
 }
 
 // validateVRRPInstances validates existing configuration and sets the default
@@ -253,31 +615,65 @@ func (k *KeepalivedSpec) validateVirtualServers() []error {
 		if k.VirtualServers[i].DelayLoop < 0 {
 			errs = append(errs, errors.New("DelayLoop must be a positive integer"))
 		}
+
+		errs = append(errs, k.VirtualServers[i].HealthCheck.validate()...)
+
+		for j := range k.VirtualServers[i].RealServers {
+			rs := &k.VirtualServers[i].RealServers[j]
+			if rs.IPAddress == "" || net.ParseIP(rs.IPAddress) == nil {
+				errs = append(errs, fmt.Errorf("invalid RealServer IP address: %s", rs.IPAddress))
+			}
+			if rs.Weight == 0 {
+				rs.Weight = 1
+			} else if rs.Weight < 0 {
+				errs = append(errs, errors.New("RealServer Weight must be a positive integer"))
+			}
+			errs = append(errs, rs.HealthCheck.validate()...)
+		}
 	}
 	return errs
 }
 
-// Validate validates the ControlPlaneLoadBalancingSpec
-func (c *ControlPlaneLoadBalancingSpec) Validate(externalAddress string) []error {
+// Validate validates the ControlPlaneLoadBalancingSpec. eipPools is an
+// optional list of the cluster's EIPPool resources, used to additionally
+// reject pools that overlap each other or intersect a Keepalived
+// VirtualServer address; it may be omitted where that cluster-wide state
+// isn't available. ServiceLB itself is not inspected.
+func (c *ControlPlaneLoadBalancingSpec) Validate(externalAddress string, eipPools ...EIPPool) []error {
 	if c == nil {
 		return nil
 	}
 	errs := []error{}
 
 	switch c.Type {
-	case CPLBTypeKeepalived:
+	case CPLBTypeKeepalived, CPLBTypeBGP:
 	case "":
 		c.Type = CPLBTypeKeepalived
 	default:
-		errs = append(errs, fmt.Errorf("unsupported CPLB type: %s. Only allowed value: %s", c.Type, CPLBTypeKeepalived))
+		errs = append(errs, fmt.Errorf("unsupported CPLB type: %s. Allowed values: %s, %s", c.Type, CPLBTypeKeepalived, CPLBTypeBGP))
+	}
+
+	if c.Keepalived != nil {
+		errs = append(errs, c.Keepalived.validateVRRPInstances(nil)...)
+		errs = append(errs, c.Keepalived.validateVirtualServers()...)
 	}
+	errs = append(errs, c.BGP.validateBGP()...)
 
-	errs = append(errs, c.Keepalived.validateVRRPInstances(nil)...)
-	errs = append(errs, c.Keepalived.validateVirtualServers()...)
 	// CPLB reconciler relies in watching kubernetes.default.svc endpoints
-	if externalAddress != "" && len(c.Keepalived.VirtualServers) > 0 {
+	if externalAddress != "" && c.Keepalived != nil && len(c.Keepalived.VirtualServers) > 0 {
 		errs = append(errs, errors.New(".spec.api.externalAddress and VRRPInstances cannot be used together"))
 	}
+	if externalAddress != "" && c.BGP != nil && len(c.BGP.VIPs) > 0 {
+		errs = append(errs, errors.New(".spec.api.externalAddress and BGP VIPs cannot be used together"))
+	}
+
+	if len(eipPools) > 0 {
+		var virtualServers VirtualServers
+		if c.Keepalived != nil {
+			virtualServers = c.Keepalived.VirtualServers
+		}
+		errs = append(errs, ValidateEIPPools(eipPools, virtualServers)...)
+	}
 
 	return errs
 }
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateNotifyScript(t *testing.T) {
+	t.Run("nil script", func(t *testing.T) {
+		if errs := validateNotifyScript(nil, false); len(errs) != 0 {
+			t.Fatalf("expected no errors for a nil script, got: %v", errs)
+		}
+	})
+
+	t.Run("missing Command", func(t *testing.T) {
+		errs := validateNotifyScript(&NotifyScript{}, false)
+		if len(errs) == 0 {
+			t.Fatal("expected an error for a missing Command")
+		}
+	})
+
+	t.Run("valid script, security disabled", func(t *testing.T) {
+		errs := validateNotifyScript(&NotifyScript{Command: "/bin/true"}, false)
+		if len(errs) != 0 {
+			t.Fatalf("expected no errors, got: %v", errs)
+		}
+	})
+
+	t.Run("world-writable script rejected when security enabled", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notify.sh")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0666); err != nil {
+			t.Fatalf("failed to create test script: %v", err)
+		}
+		// WriteFile's mode is subject to the umask; make it world-writable.
+		if err := os.Chmod(path, 0666); err != nil {
+			t.Fatalf("failed to chmod test script: %v", err)
+		}
+
+		errs := validateNotifyScript(&NotifyScript{Command: path}, true)
+		if len(errs) == 0 {
+			t.Fatal("expected an error for a world-writable NotifyScript command")
+		}
+	})
+
+	t.Run("script security check skipped when disabled", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notify.sh")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0666); err != nil {
+			t.Fatalf("failed to create test script: %v", err)
+		}
+
+		errs := validateNotifyScript(&NotifyScript{Command: path}, false)
+		if len(errs) != 0 {
+			t.Fatalf("expected no errors with script security disabled, got: %v", errs)
+		}
+	})
+}
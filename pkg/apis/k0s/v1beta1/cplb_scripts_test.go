@@ -0,0 +1,164 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeepalivedSpec_ValidateVRRPScripts(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  VRRPScript
+		wantErr bool
+	}{
+		{name: "valid", script: VRRPScript{Name: "check-apiserver", Script: "/bin/true"}, wantErr: false},
+		{name: "missing name", script: VRRPScript{Script: "/bin/true"}, wantErr: true},
+		{name: "missing script", script: VRRPScript{Name: "check-apiserver"}, wantErr: true},
+		{name: "weight too low", script: VRRPScript{Name: "check-apiserver", Script: "/bin/true", Weight: -255}, wantErr: true},
+		{name: "weight too high", script: VRRPScript{Name: "check-apiserver", Script: "/bin/true", Weight: 255}, wantErr: true},
+		{name: "weight at lower bound", script: VRRPScript{Name: "check-apiserver", Script: "/bin/true", Weight: -254}, wantErr: false},
+		{name: "weight at upper bound", script: VRRPScript{Name: "check-apiserver", Script: "/bin/true", Weight: 254}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &KeepalivedSpec{VRRPScripts: []VRRPScript{tt.script}}
+			errs := k.validateVRRPScripts()
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
+
+func TestKeepalivedSpec_ValidateVRRPScripts_Defaults(t *testing.T) {
+	k := &KeepalivedSpec{VRRPScripts: []VRRPScript{{Name: "check-apiserver", Script: "/bin/true"}}}
+	if errs := k.validateVRRPScripts(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+
+	s := k.VRRPScripts[0]
+	if s.Interval != 1 {
+		t.Errorf("expected Interval to default to 1, got %d", s.Interval)
+	}
+	if s.Fall != 1 {
+		t.Errorf("expected Fall to default to 1, got %d", s.Fall)
+	}
+	if s.Rise != 1 {
+		t.Errorf("expected Rise to default to 1, got %d", s.Rise)
+	}
+	if s.Timeout != s.Interval {
+		t.Errorf("expected Timeout to default to Interval (%d), got %d", s.Interval, s.Timeout)
+	}
+}
+
+func TestKeepalivedSpec_HasVRRPScript(t *testing.T) {
+	k := &KeepalivedSpec{VRRPScripts: []VRRPScript{{Name: "check-apiserver", Script: "/bin/true"}}}
+
+	if !k.hasVRRPScript("check-apiserver") {
+		t.Error("expected hasVRRPScript to find a defined script")
+	}
+	if k.hasVRRPScript("check-etcd") {
+		t.Error("expected hasVRRPScript to not find an undefined script")
+	}
+}
+
+func TestKeepalivedSpec_ValidateVRRPInstances_UndefinedTrackScript(t *testing.T) {
+	virtualRouterID := int32(51)
+	advertInterval := int32(1)
+	k := &KeepalivedSpec{
+		VRRPInstances: VRRPInstances{{
+			VirtualIPs:      VirtualIPs{"192.0.2.100/32"},
+			Interface:       "eth0",
+			VirtualRouterID: &virtualRouterID,
+			AdvertInterval:  &advertInterval,
+			AuthPass:        "secret",
+			TrackScripts:    []string{"check-apiserver"},
+		}},
+	}
+
+	errs := k.validateVRRPInstances(func() (string, error) { return "eth0", nil })
+	found := false
+	for _, err := range errs {
+		if err != nil && err.Error() == "TrackScripts references undefined VRRPScript: check-apiserver" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an error about the undefined VRRPScript, got: %v", errs)
+	}
+}
+
+func TestValidateScriptOwnership(t *testing.T) {
+	t.Run("missing script is soft-warned, not rejected", func(t *testing.T) {
+		if err := validateScriptOwnership(filepath.Join(t.TempDir(), "does-not-exist")); err != nil {
+			t.Fatalf("expected no error for a missing script, got: %v", err)
+		}
+	})
+
+	t.Run("empty path is rejected", func(t *testing.T) {
+		if err := validateScriptOwnership(""); err == nil {
+			t.Fatal("expected an error for an empty path")
+		}
+	})
+
+	t.Run("world-writable script is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "script.sh")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0666); err != nil {
+			t.Fatalf("failed to create test script: %v", err)
+		}
+		// WriteFile's mode is subject to the umask; make it world-writable.
+		if err := os.Chmod(path, 0666); err != nil {
+			t.Fatalf("failed to chmod test script: %v", err)
+		}
+		if err := validateScriptOwnership(path); err == nil {
+			t.Fatal("expected an error for a world-writable script")
+		}
+	})
+
+	t.Run("root-owned script is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "script.sh")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0700); err != nil {
+			t.Fatalf("failed to create test script: %v", err)
+		}
+		if os.Geteuid() != 0 {
+			t.Skip("test requires running as root to produce a root-owned file")
+		}
+		if err := validateScriptOwnership(path); err == nil {
+			t.Fatal("expected an error for a root-owned script")
+		}
+	})
+
+	t.Run("non-root-owned, non-writable script passes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "script.sh")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0700); err != nil {
+			t.Fatalf("failed to create test script: %v", err)
+		}
+		if err := os.Chown(path, 1000, 1000); err != nil {
+			t.Skipf("cannot chown test script away from root in this environment: %v", err)
+		}
+		if err := validateScriptOwnership(path); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "testing"
+
+func validBGPSpec() *BGPSpec {
+	return &BGPSpec{
+		LocalASN:  65000,
+		Neighbors: []BGPNeighbor{{PeerAddress: "192.0.2.1", RemoteASN: 65001}},
+		VIPs:      []BGPVIP{{VirtualIP: "192.0.2.100/32"}},
+	}
+}
+
+func TestControlPlaneLoadBalancingSpec_Validate_NilKeepalived(t *testing.T) {
+	c := &ControlPlaneLoadBalancingSpec{
+		Type: CPLBTypeBGP,
+		BGP:  validBGPSpec(),
+	}
+
+	if errs := c.Validate(""); len(errs) != 0 {
+		t.Fatalf("expected no errors for a BGP-only spec, got: %v", errs)
+	}
+}
+
+func TestControlPlaneLoadBalancingSpec_Validate_NilBoth(t *testing.T) {
+	c := &ControlPlaneLoadBalancingSpec{}
+
+	// Neither Keepalived nor BGP set: must not panic.
+	if errs := c.Validate(""); len(errs) != 0 {
+		t.Fatalf("expected no errors for an empty spec, got: %v", errs)
+	}
+}
+
+func TestBGPSpec_ValidateBGP_ASNBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		localASN  int64
+		remoteASN int64
+		wantErr   bool
+	}{
+		{name: "minimum valid ASN", localASN: minASN, remoteASN: minASN, wantErr: false},
+		{name: "maximum 4-byte ASN", localASN: maxASN, remoteASN: maxASN, wantErr: false},
+		{name: "zero local ASN", localASN: 0, remoteASN: 65001, wantErr: true},
+		{name: "negative remote ASN", localASN: 65000, remoteASN: -1, wantErr: true},
+		{name: "local ASN beyond 4-byte range", localASN: maxASN + 1, remoteASN: 65001, wantErr: true},
+		{name: "remote ASN beyond 4-byte range", localASN: 65000, remoteASN: maxASN + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BGPSpec{
+				LocalASN:  tt.localASN,
+				Neighbors: []BGPNeighbor{{PeerAddress: "192.0.2.1", RemoteASN: tt.remoteASN}},
+				VIPs:      []BGPVIP{{VirtualIP: "192.0.2.100/32"}},
+			}
+
+			errs := b.validateBGP()
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "testing"
+
+func TestValidateUnicastPeers(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       VRRPInstance
+		wantErr bool
+	}{
+		{
+			name:    "no peers configured",
+			v:       VRRPInstance{},
+			wantErr: false,
+		},
+		{
+			name:    "auto discovery sentinel",
+			v:       VRRPInstance{UnicastPeers: []string{UnicastPeersAuto}},
+			wantErr: false,
+		},
+		{
+			// The interface does not exist on the test host, so the
+			// presence check of UnicastSourceIP is skipped.
+			name:    "valid static peers",
+			v:       VRRPInstance{Interface: "k0s-test-nic0", UnicastSourceIP: "192.0.2.1", UnicastPeers: []string{"192.0.2.2", "192.0.2.3"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid UnicastSourceIP",
+			v:       VRRPInstance{UnicastSourceIP: "not-an-ip", UnicastPeers: []string{"192.0.2.2"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid UnicastPeers entry",
+			v:       VRRPInstance{UnicastPeers: []string{"not-an-ip"}},
+			wantErr: true,
+		},
+		{
+			name:    "UnicastPeers family mismatch with UnicastSourceIP",
+			v:       VRRPInstance{UnicastSourceIP: "192.0.2.1", UnicastPeers: []string{"2001:db8::1"}},
+			wantErr: true,
+		},
+		{
+			name:    "UnicastPeers contains UnicastSourceIP",
+			v:       VRRPInstance{UnicastSourceIP: "192.0.2.1", UnicastPeers: []string{"192.0.2.1"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateUnicastPeers(&tt.v)
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
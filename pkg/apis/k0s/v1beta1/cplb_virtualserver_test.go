@@ -0,0 +1,68 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "testing"
+
+func TestKeepalivedSpec_ValidateVirtualServers_RealServers(t *testing.T) {
+	tests := []struct {
+		name    string
+		rs      RealServer
+		wantErr bool
+	}{
+		{name: "valid", rs: RealServer{IPAddress: "192.0.2.10", Weight: 1}, wantErr: false},
+		{name: "default weight", rs: RealServer{IPAddress: "192.0.2.10"}, wantErr: false},
+		{name: "empty IPAddress", rs: RealServer{IPAddress: ""}, wantErr: true},
+		{name: "invalid IPAddress", rs: RealServer{IPAddress: "not-an-ip"}, wantErr: true},
+		{name: "negative weight", rs: RealServer{IPAddress: "192.0.2.10", Weight: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &KeepalivedSpec{
+				VirtualServers: VirtualServers{{
+					IPAddress:   "192.0.2.1",
+					RealServers: []RealServer{tt.rs},
+				}},
+			}
+
+			errs := k.validateVirtualServers()
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
+
+func TestKeepalivedSpec_ValidateVirtualServers_RealServerWeightDefault(t *testing.T) {
+	k := &KeepalivedSpec{
+		VirtualServers: VirtualServers{{
+			IPAddress:   "192.0.2.1",
+			RealServers: []RealServer{{IPAddress: "192.0.2.10"}},
+		}},
+	}
+
+	if errs := k.validateVirtualServers(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+	if w := k.VirtualServers[0].RealServers[0].Weight; w != 1 {
+		t.Errorf("expected Weight to default to 1, got %d", w)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EIPPoolFinalizer is attached to EIPPool objects that still have addresses
+// allocated to in-use Services, so the pool cannot be removed until every
+// allocation has been released.
+const EIPPoolFinalizer = "k0s.io/eippool-protection"
+
+// LoadBalancerClass is the value k0s claims in the
+// "service.kubernetes.io/loadbalancer-class" annotation/field of a Service,
+// so that it only allocates addresses for Services explicitly addressed to
+// the CPLB-backed implementation.
+const LoadBalancerClass = "k0s.io/cplb"
+
+// ServiceLBProtocol describes how an EIPPool's addresses are announced to
+// the network.
+// +kubebuilder:validation:Enum=layer2;bgp
+type ServiceLBProtocol string
+
+const (
+	// ServiceLBProtocolLayer2 announces addresses as additional
+	// VRRPInstance.VirtualIPs entries, handled by Keepalived.
+	ServiceLBProtocolLayer2 ServiceLBProtocol = "layer2"
+	// ServiceLBProtocolBGP announces addresses as BGP routes, handled by the
+	// BGP speaker.
+	ServiceLBProtocolBGP ServiceLBProtocol = "bgp"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EIPPool is a cluster-scoped resource that reserves one or more CIDR
+// ranges from which k0s allocates addresses for Kubernetes Service objects
+// of type LoadBalancer, announcing them via the existing CPLB machinery.
+// This package only defines and validates the schema; the allocation
+// controller that watches Services and assigns addresses from the pool
+// lives elsewhere.
+type EIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EIPPoolSpec   `json:"spec"`
+	Status EIPPoolStatus `json:"status,omitempty"`
+}
+
+// EIPPoolSpec defines the desired state of an EIPPool.
+type EIPPoolSpec struct {
+	// CIDRs is the list of address ranges reserved by this pool.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	CIDRs []string `json:"cidrs"`
+
+	// Disabled excludes the pool from allocation without deleting it, so
+	// that existing allocations are kept but no new ones are made from it.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// NodeSelector restricts which nodes may announce addresses from this
+	// pool. An empty selector matches all control plane nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Protocol selects how addresses from this pool are announced. If not
+	// specified, defaults to "layer2".
+	// +kubebuilder:default=layer2
+	// +optional
+	Protocol ServiceLBProtocol `json:"protocol,omitempty"`
+
+	// Interface is a hint for the NIC used to announce layer2 addresses
+	// from this pool. If not specified, k0s uses the interface that owns
+	// the default route.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+}
+
+// EIPPoolStatus defines the observed state of an EIPPool.
+type EIPPoolStatus struct {
+	// AllocatedAddresses is the number of addresses currently allocated to
+	// Services from this pool.
+	// +optional
+	AllocatedAddresses int `json:"allocatedAddresses,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EIPPoolList is a list of EIPPool resources.
+type EIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EIPPool `json:"items"`
+}
+
+// Validate validates the EIPPoolSpec, rejecting malformed or internally
+// overlapping CIDRs.
+func (e *EIPPool) Validate() []error {
+	if e == nil {
+		return nil
+	}
+	errs := []error{}
+
+	if len(e.Spec.CIDRs) == 0 {
+		errs = append(errs, errors.New("CIDRs must be defined"))
+	}
+
+	var nets []*net.IPNet
+	for _, cidr := range e.Spec.CIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CIDRs must be a CIDR. Got: %s", cidr))
+			continue
+		}
+		for _, other := range nets {
+			if cidrsOverlap(ipNet, other) {
+				errs = append(errs, fmt.Errorf("CIDRs %s and %s overlap", cidr, other.String()))
+			}
+		}
+		nets = append(nets, ipNet)
+	}
+
+	switch e.Spec.Protocol {
+	case "", ServiceLBProtocolLayer2, ServiceLBProtocolBGP:
+	default:
+		errs = append(errs, fmt.Errorf("invalid Protocol: %s", e.Spec.Protocol))
+	}
+
+	return errs
+}
+
+// cidrsOverlap reports whether a and b share at least one address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// ValidateEIPPools validates a set of EIPPool resources together, in
+// addition to each pool's own Validate, rejecting CIDRs that overlap across
+// pools and pools that intersect any of the given VirtualServer addresses.
+func ValidateEIPPools(pools []EIPPool, virtualServers VirtualServers) []error {
+	errs := []error{}
+
+	var allNets []struct {
+		name string
+		net  *net.IPNet
+	}
+	for i := range pools {
+		errs = append(errs, pools[i].Validate()...)
+		for _, cidr := range pools[i].Spec.CIDRs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				continue
+			}
+			for _, other := range allNets {
+				if other.name != pools[i].Name && cidrsOverlap(ipNet, other.net) {
+					errs = append(errs, fmt.Errorf("EIPPool %s CIDR %s overlaps with EIPPool %s", pools[i].Name, cidr, other.name))
+				}
+			}
+			allNets = append(allNets, struct {
+				name string
+				net  *net.IPNet
+			}{pools[i].Name, ipNet})
+
+			for _, vs := range virtualServers {
+				ip := net.ParseIP(vs.IPAddress)
+				if ip != nil && ipNet.Contains(ip) {
+					errs = append(errs, fmt.Errorf("EIPPool %s CIDR %s intersects VirtualServer IPAddress %s", pools[i].Name, cidr, vs.IPAddress))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// ServiceLBSpec toggles allocation of type=LoadBalancer Service addresses
+// from a set of EIPPool resources, programmed into the CPLB machinery.
+type ServiceLBSpec struct {
+	// Enabled indicates if Service type=LoadBalancer support should be
+	// enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Pools is the list of EIPPool names eligible for allocation. An empty
+	// list allows allocation from any non-disabled EIPPool.
+	// +optional
+	Pools []string `json:"pools,omitempty"`
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func objMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name}
+}
+
+func TestValidateEIPPools(t *testing.T) {
+	tests := []struct {
+		name           string
+		pools          []EIPPool
+		virtualServers VirtualServers
+		wantErr        bool
+	}{
+		{
+			name: "non-overlapping pools",
+			pools: []EIPPool{
+				{ObjectMeta: objMeta("a"), Spec: EIPPoolSpec{CIDRs: []string{"192.0.2.0/24"}}},
+				{ObjectMeta: objMeta("b"), Spec: EIPPoolSpec{CIDRs: []string{"198.51.100.0/24"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "overlapping pools",
+			pools: []EIPPool{
+				{ObjectMeta: objMeta("a"), Spec: EIPPoolSpec{CIDRs: []string{"192.0.2.0/24"}}},
+				{ObjectMeta: objMeta("b"), Spec: EIPPoolSpec{CIDRs: []string{"192.0.2.128/25"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pool intersects VirtualServer",
+			pools: []EIPPool{
+				{ObjectMeta: objMeta("a"), Spec: EIPPoolSpec{CIDRs: []string{"192.0.2.0/24"}}},
+			},
+			virtualServers: VirtualServers{{IPAddress: "192.0.2.10"}},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateEIPPools(tt.pools, tt.virtualServers)
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
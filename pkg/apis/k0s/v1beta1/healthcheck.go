@@ -0,0 +1,241 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// HealthCheckType describes the kind of health check keepalived performs
+// against a real server.
+// +kubebuilder:validation:Enum=TCP_CHECK;HTTP_GET;SSL_GET;MISC_CHECK
+type HealthCheckType string
+
+const (
+	// TCPCheckType performs a plain TCP connect check.
+	TCPCheckType HealthCheckType = "TCP_CHECK"
+	// HTTPGetCheckType performs an HTTP GET check.
+	HTTPGetCheckType HealthCheckType = "HTTP_GET"
+	// SSLGetCheckType performs an HTTPS GET check.
+	SSLGetCheckType HealthCheckType = "SSL_GET"
+	// MiscCheckType runs an external script to determine health, optionally
+	// using its exit code as a dynamic weight.
+	MiscCheckType HealthCheckType = "MISC_CHECK"
+)
+
+// HealthCheck defines a keepalived real_server health check. Exactly one of
+// TCPCheck, HTTPGet, SSLGet or MiscCheck must be set, matching Type.
+type HealthCheck struct {
+	// Type selects the kind of health check to perform.
+	// +kubebuilder:validation:Required
+	Type HealthCheckType `json:"type"`
+
+	// ConnectTimeout is the number of seconds to wait for a connection to
+	// the real server. If not specified, defaults to 5.
+	// +kubebuilder:default=5
+	// +optional
+	ConnectTimeout int `json:"connectTimeout,omitempty"`
+
+	// RetryCount is the number of times to retry a failed check before
+	// marking the real server down. If not specified, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+
+	// DelayBeforeRetry is the number of seconds to wait between retries. If
+	// not specified, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	DelayBeforeRetry int `json:"delayBeforeRetry,omitempty"`
+
+	// TCPCheck configures a TCP_CHECK. Must be set when Type is TCP_CHECK.
+	// +optional
+	TCPCheck *TCPCheck `json:"tcpCheck,omitempty"`
+
+	// HTTPGet configures an HTTP_GET check. Must be set when Type is
+	// HTTP_GET.
+	// +optional
+	HTTPGet *HTTPCheck `json:"httpGet,omitempty"`
+
+	// SSLGet configures an SSL_GET check. Must be set when Type is SSL_GET.
+	// +optional
+	SSLGet *HTTPCheck `json:"sslGet,omitempty"`
+
+	// MiscCheck configures a MISC_CHECK. Must be set when Type is
+	// MISC_CHECK.
+	// +optional
+	MiscCheck *MiscCheck `json:"miscCheck,omitempty"`
+}
+
+// TCPCheck defines the options for a TCP_CHECK health check.
+type TCPCheck struct {
+	// ConnectPort overrides the port used to connect to the real server. If
+	// not specified, the virtual server's port is used.
+	// +optional
+	ConnectPort int `json:"connectPort,omitempty"`
+}
+
+// HTTPCheck defines the options shared by HTTP_GET and SSL_GET health
+// checks.
+type HTTPCheck struct {
+	// Path is the URL path requested on the real server.
+	// +kubebuilder:default="/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ConnectPort overrides the port used to connect to the real server. If
+	// not specified, the virtual server's port is used.
+	// +optional
+	ConnectPort int `json:"connectPort,omitempty"`
+
+	// Digest is the expected MD5 digest of the response body. Mutually
+	// exclusive with StatusCode.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// StatusCode is the list of HTTP status codes considered healthy.
+	// Mutually exclusive with Digest.
+	// +optional
+	StatusCode []int `json:"statusCode,omitempty"`
+
+	// NbGetRetry is the number of times to retry the request before marking
+	// the check as failed. If not specified, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	NbGetRetry int `json:"nbGetRetry,omitempty"`
+
+	// DelayBeforeRetry is the number of seconds to wait between request
+	// retries. If not specified, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	DelayBeforeRetry int `json:"delayBeforeRetry,omitempty"`
+}
+
+// MiscCheck defines the options for a MISC_CHECK health check.
+type MiscCheck struct {
+	// Script is the external command run to check the real server's health.
+	// +kubebuilder:validation:Required
+	Script string `json:"script"`
+
+	// Timeout is the number of seconds after which the script is considered
+	// to have failed if it has not completed.
+	// +optional
+	Timeout int `json:"timeout,omitempty"`
+
+	// DynamicWeight, when true, uses the script's exit code (0-255) as the
+	// real server's weight instead of treating any non-zero exit as a
+	// failure.
+	// +optional
+	DynamicWeight bool `json:"dynamicWeight,omitempty"`
+}
+
+// validate validates the HealthCheck and sets the default values of
+// undefined fields.
+func (h *HealthCheck) validate() []error {
+	if h == nil {
+		return nil
+	}
+	errs := []error{}
+
+	if h.ConnectTimeout == 0 {
+		h.ConnectTimeout = 5
+	} else if h.ConnectTimeout < 0 {
+		errs = append(errs, errors.New("HealthCheck ConnectTimeout must be a positive integer"))
+	}
+	if h.RetryCount == 0 {
+		h.RetryCount = 1
+	} else if h.RetryCount < 0 {
+		errs = append(errs, errors.New("HealthCheck RetryCount must be a positive integer"))
+	}
+	if h.DelayBeforeRetry == 0 {
+		h.DelayBeforeRetry = 1
+	} else if h.DelayBeforeRetry < 0 {
+		errs = append(errs, errors.New("HealthCheck DelayBeforeRetry must be a positive integer"))
+	}
+
+	checks := map[HealthCheckType]bool{}
+	if h.TCPCheck != nil {
+		checks[TCPCheckType] = true
+	}
+	if h.HTTPGet != nil {
+		checks[HTTPGetCheckType] = true
+	}
+	if h.SSLGet != nil {
+		checks[SSLGetCheckType] = true
+	}
+	if h.MiscCheck != nil {
+		checks[MiscCheckType] = true
+	}
+	if len(checks) > 1 {
+		errs = append(errs, errors.New("HealthCheck only one of TCPCheck, HTTPGet, SSLGet or MiscCheck may be set"))
+	}
+
+	switch h.Type {
+	case TCPCheckType:
+		if h.TCPCheck == nil {
+			errs = append(errs, errors.New("HealthCheck TCPCheck must be defined when Type is TCP_CHECK"))
+		}
+	case HTTPGetCheckType:
+		errs = append(errs, validateHTTPCheck(h.HTTPGet, "HTTPGet")...)
+	case SSLGetCheckType:
+		errs = append(errs, validateHTTPCheck(h.SSLGet, "SSLGet")...)
+	case MiscCheckType:
+		if h.MiscCheck == nil {
+			errs = append(errs, errors.New("HealthCheck MiscCheck must be defined when Type is MISC_CHECK"))
+		} else if h.MiscCheck.Script == "" {
+			errs = append(errs, errors.New("HealthCheck MiscCheck Script must be defined"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid HealthCheck Type: %s", h.Type))
+	}
+
+	return errs
+}
+
+func validateHTTPCheck(c *HTTPCheck, field string) []error {
+	errs := []error{}
+	if c == nil {
+		errs = append(errs, fmt.Errorf("HealthCheck %s must be defined", field))
+		return errs
+	}
+	if c.Digest != "" && len(c.StatusCode) > 0 {
+		errs = append(errs, fmt.Errorf("HealthCheck %s Digest and StatusCode are mutually exclusive", field))
+	}
+	if c.Digest == "" && len(c.StatusCode) == 0 {
+		errs = append(errs, fmt.Errorf("HealthCheck %s either Digest or StatusCode must be defined", field))
+	}
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	if _, err := url.ParseRequestURI(path); err != nil {
+		errs = append(errs, fmt.Errorf("HealthCheck %s invalid Path: %s", field, c.Path))
+	}
+	if c.NbGetRetry == 0 {
+		c.NbGetRetry = 1
+	} else if c.NbGetRetry < 0 {
+		errs = append(errs, fmt.Errorf("HealthCheck %s NbGetRetry must be a positive integer", field))
+	}
+	if c.DelayBeforeRetry == 0 {
+		c.DelayBeforeRetry = 1
+	} else if c.DelayBeforeRetry < 0 {
+		errs = append(errs, fmt.Errorf("HealthCheck %s DelayBeforeRetry must be a positive integer", field))
+	}
+	return errs
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 k0s authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "testing"
+
+func TestHealthCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   HealthCheck
+		wantErr bool
+	}{
+		{
+			name:    "valid TCP check",
+			check:   HealthCheck{Type: TCPCheckType, TCPCheck: &TCPCheck{}},
+			wantErr: false,
+		},
+		{
+			name:    "TCP type without TCPCheck",
+			check:   HealthCheck{Type: TCPCheckType},
+			wantErr: true,
+		},
+		{
+			name:    "valid HTTP_GET check with StatusCode",
+			check:   HealthCheck{Type: HTTPGetCheckType, HTTPGet: &HTTPCheck{StatusCode: []int{200}}},
+			wantErr: false,
+		},
+		{
+			name:    "HTTP_GET type without HTTPGet",
+			check:   HealthCheck{Type: HTTPGetCheckType},
+			wantErr: true,
+		},
+		{
+			name:    "valid MISC_CHECK",
+			check:   HealthCheck{Type: MiscCheckType, MiscCheck: &MiscCheck{Script: "/bin/true"}},
+			wantErr: false,
+		},
+		{
+			name:    "MISC_CHECK without Script",
+			check:   HealthCheck{Type: MiscCheckType, MiscCheck: &MiscCheck{}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid Type",
+			check:   HealthCheck{Type: "bogus"},
+			wantErr: true,
+		},
+		{
+			name: "more than one check type set",
+			check: HealthCheck{
+				Type:     TCPCheckType,
+				TCPCheck: &TCPCheck{},
+				HTTPGet:  &HTTPCheck{StatusCode: []int{200}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "negative ConnectTimeout",
+			check:   HealthCheck{Type: TCPCheckType, TCPCheck: &TCPCheck{}, ConnectTimeout: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.check.validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}
+
+func TestHealthCheck_Validate_Defaults(t *testing.T) {
+	h := &HealthCheck{Type: TCPCheckType, TCPCheck: &TCPCheck{}}
+	if errs := h.validate(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+	if h.ConnectTimeout != 5 {
+		t.Errorf("expected ConnectTimeout to default to 5, got %d", h.ConnectTimeout)
+	}
+	if h.RetryCount != 1 {
+		t.Errorf("expected RetryCount to default to 1, got %d", h.RetryCount)
+	}
+	if h.DelayBeforeRetry != 1 {
+		t.Errorf("expected DelayBeforeRetry to default to 1, got %d", h.DelayBeforeRetry)
+	}
+}
+
+func TestValidateHTTPCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   *HTTPCheck
+		wantErr bool
+	}{
+		{name: "nil check", check: nil, wantErr: true},
+		{name: "StatusCode only", check: &HTTPCheck{StatusCode: []int{200, 204}}, wantErr: false},
+		{name: "Digest only", check: &HTTPCheck{Digest: "d41d8cd98f00b204e9800998ecf8427e"}, wantErr: false},
+		{name: "both Digest and StatusCode", check: &HTTPCheck{Digest: "d41d8cd98f00b204e9800998ecf8427e", StatusCode: []int{200}}, wantErr: true},
+		{name: "neither Digest nor StatusCode", check: &HTTPCheck{}, wantErr: true},
+		{name: "invalid Path", check: &HTTPCheck{StatusCode: []int{200}, Path: "://not-a-path"}, wantErr: true},
+		{name: "negative NbGetRetry", check: &HTTPCheck{StatusCode: []int{200}, NbGetRetry: -1}, wantErr: true},
+		{name: "negative DelayBeforeRetry", check: &HTTPCheck{StatusCode: []int{200}, DelayBeforeRetry: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateHTTPCheck(tt.check, "HTTPGet")
+			if tt.wantErr && len(errs) == 0 {
+				t.Fatalf("expected errors, got none")
+			}
+			if !tt.wantErr && len(errs) != 0 {
+				t.Fatalf("expected no errors, got: %v", errs)
+			}
+		})
+	}
+}